@@ -0,0 +1,110 @@
+package broadcast
+
+import (
+	"context"
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authMetadataKey = "authorization"
+const bearerPrefix = "Bearer "
+
+// serverOptions builds the grpc.ServerOption set for cfg: TLS transport
+// credentials when a cert/key pair is configured, plus token-checking
+// interceptors when an AuthToken is configured.
+func (s *Server) serverOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	if s.cfg.TLSCertFile != "" && s.cfg.TLSKeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.cfg.TLSCertFile, s.cfg.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	if s.cfg.AuthToken != "" {
+		opts = append(opts,
+			grpc.UnaryInterceptor(s.unaryAuthInterceptor),
+			grpc.StreamInterceptor(s.streamAuthInterceptor),
+		)
+	}
+
+	return opts, nil
+}
+
+func (s *Server) unaryAuthInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.checkToken(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuthInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.checkToken(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+func (s *Server) checkToken(ctx context.Context) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	values := md.Get(authMetadataKey)
+	if len(values) != 1 || values[0] != bearerPrefix+s.cfg.AuthToken {
+		return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+	}
+	return nil
+}
+
+// DialOptions builds the grpc.DialOption set a broadcast client needs to
+// connect to a Server: TLS using caFile (or the system trust store if
+// caFile is empty) when useTLS is true, plus a bearer token credential when
+// token is non-empty. Pass useTLS=false for the insecure, plaintext dev mode.
+func DialOptions(useTLS bool, caFile string, token string) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if useTLS {
+		var tlsConfig *tls.Config
+		if caFile != "" {
+			creds, err := credentials.NewClientTLSFromFile(caFile, "")
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpc.WithTransportCredentials(creds))
+		} else {
+			opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		}
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{token: token, requireTLS: useTLS}))
+	}
+
+	return opts, nil
+}
+
+// tokenCredentials implements credentials.PerRPCCredentials, attaching a
+// bearer token to every RPC's metadata.
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authMetadataKey: bearerPrefix + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
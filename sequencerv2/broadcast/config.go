@@ -0,0 +1,44 @@
+package broadcast
+
+import "time"
+
+// Config is the broadcast service configuration.
+type Config struct {
+	// Host is the address the broadcast gRPC server listens on.
+	Host string `mapstructure:"Host"`
+
+	// Port is the port the broadcast gRPC server listens on.
+	Port int `mapstructure:"Port"`
+
+	// PollingInterval is how often the server checks the state DB for newly
+	// sealed batches to fan out to SubscribeBatches subscribers.
+	PollingInterval time.Duration `mapstructure:"PollingInterval"`
+
+	// TLSCertFile and TLSKeyFile, when both set, make the server accept only
+	// TLS connections using this certificate. Leave empty to keep serving
+	// plaintext, insecure connections (the default for local/dev setups).
+	TLSCertFile string `mapstructure:"TLSCertFile"`
+	TLSKeyFile  string `mapstructure:"TLSKeyFile"`
+
+	// AuthToken, when set, is the shared bearer token every RPC must present
+	// in the "authorization" metadata to be served. Leave empty to disable
+	// auth, which is only acceptable for local/dev setups.
+	AuthToken string `mapstructure:"AuthToken"`
+
+	// MetricsHost and MetricsPort, when MetricsPort is non-zero, expose a
+	// Prometheus /metrics endpoint with RPC and DB query timings.
+	MetricsHost string `mapstructure:"MetricsHost"`
+	MetricsPort int    `mapstructure:"MetricsPort"`
+
+	// Debug holds opt-in switches for operator-facing diagnostics.
+	Debug DebugConfig `mapstructure:"Debug"`
+}
+
+// DebugConfig groups opt-in diagnostic switches.
+type DebugConfig struct {
+	// Timers, when true, logs per-RPC durations at INFO for GetBatch and
+	// GetLastBatch, broken down into the DB fetch and payload marshalling
+	// phases, for performance tuning. The network send phase happens inside
+	// grpc-go after the handler returns and isn't observable from here.
+	Timers bool `mapstructure:"Timers"`
+}
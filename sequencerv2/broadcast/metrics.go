@@ -0,0 +1,102 @@
+package broadcast
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hermeznetwork/hermez-core/log"
+	"github.com/hermeznetwork/hermez-core/sequencerv2/broadcast/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/protobuf/proto"
+)
+
+const metricsNamespace = "broadcast"
+
+var (
+	getBatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "get_batch_seconds",
+		Help:      "Duration of GetBatch calls, in seconds",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	getLastBatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "get_last_batch_seconds",
+		Help:      "Duration of GetLastBatch calls, in seconds",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	streamEntriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "stream_entries_total",
+		Help:      "Total number of StreamEntry messages sent over StreamFrom",
+	})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "db_query_seconds",
+		Help:      "Duration of state DB queries issued by the broadcast server, bucketed by query name",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"query"})
+)
+
+// serveMetrics exposes the Prometheus handler on cfg.MetricsHost:MetricsPort.
+// It is a no-op when MetricsPort is 0, so metrics stay opt-in.
+func (s *Server) serveMetrics() {
+	if s.cfg.MetricsPort == 0 {
+		return
+	}
+
+	addr := net.JoinHostPort(s.cfg.MetricsHost, strconv.Itoa(s.cfg.MetricsPort))
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		log.Infof("broadcast metrics listening on %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Errorf("broadcast metrics server stopped: %v", err)
+		}
+	}()
+}
+
+// timedDBQuery records how long the given query takes under the db_query_seconds
+// histogram and, when Debug.Timers is enabled, logs it at INFO.
+func (s *Server) timedDBQuery(queryName string, query func() error) (time.Duration, error) {
+	start := time.Now()
+	err := query()
+	elapsed := time.Since(start)
+
+	dbQueryDuration.WithLabelValues(queryName).Observe(elapsed.Seconds())
+	if s.cfg.Debug.Timers {
+		log.Infof("broadcast: query %q took %s", queryName, elapsed)
+	}
+	return elapsed, err
+}
+
+// logRPCTimers logs, at INFO, the DB fetch and payload marshalling phases of
+// a GetBatch/GetLastBatch call, plus the total. It is a no-op unless
+// Debug.Timers is enabled. The DB fetch phase itself is already broken down
+// per query by timedDBQuery; this adds the marshalling phase and the total.
+func (s *Server) logRPCTimers(rpc string, batchNumber uint64, start, dbStart time.Time, batch *pb.Batch) {
+	if !s.cfg.Debug.Timers {
+		return
+	}
+
+	dbFetch := time.Since(dbStart)
+
+	marshalStart := time.Now()
+	_, err := proto.Marshal(batch)
+	marshal := time.Since(marshalStart)
+	if err != nil {
+		log.Errorf("broadcast: %s batch=%d failed to marshal payload for timing: %v", rpc, batchNumber, err)
+		return
+	}
+
+	log.Infof("broadcast: %s batch=%d took %s (db fetch %s, marshalling %s)",
+		rpc, batchNumber, time.Since(start), dbFetch, marshal)
+}
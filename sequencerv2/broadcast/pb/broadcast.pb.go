@@ -0,0 +1,575 @@
+// Written by hand to match broadcast.proto; see the protoreflect support
+// note below.
+// source: broadcast.proto
+
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type GetBatchRequest struct {
+	BatchNumber uint64 `protobuf:"varint,1,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *GetBatchRequest) Reset()         { *x = GetBatchRequest{} }
+func (x *GetBatchRequest) String() string { return formatMessage(x) }
+func (*GetBatchRequest) ProtoMessage()    {}
+func (x *GetBatchRequest) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: getBatchRequestSpec, ptr: x}
+}
+func (x *GetBatchRequest) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *GetBatchRequest) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *GetBatchRequest) GetBatchNumber() uint64 {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return 0
+}
+
+type SubscribeBatchesRequest struct {
+	FromBatchNumber uint64 `protobuf:"varint,1,opt,name=from_batch_number,json=fromBatchNumber,proto3" json:"from_batch_number,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *SubscribeBatchesRequest) Reset()         { *x = SubscribeBatchesRequest{} }
+func (x *SubscribeBatchesRequest) String() string { return formatMessage(x) }
+func (*SubscribeBatchesRequest) ProtoMessage()    {}
+func (x *SubscribeBatchesRequest) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: subscribeBatchesRequestSpec, ptr: x}
+}
+func (x *SubscribeBatchesRequest) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *SubscribeBatchesRequest) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *SubscribeBatchesRequest) GetFromBatchNumber() uint64 {
+	if x != nil {
+		return x.FromBatchNumber
+	}
+	return 0
+}
+
+type Batch struct {
+	BatchNumber       uint64         `protobuf:"varint,1,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty"`
+	GlobalExitRoot    string         `protobuf:"bytes,2,opt,name=global_exit_root,json=globalExitRoot,proto3" json:"global_exit_root,omitempty"`
+	Timestamp         int64          `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Sequencer         string         `protobuf:"bytes,4,opt,name=sequencer,proto3" json:"sequencer,omitempty"`
+	LocalExitRoot     string         `protobuf:"bytes,5,opt,name=local_exit_root,json=localExitRoot,proto3" json:"local_exit_root,omitempty"`
+	StateRoot         string         `protobuf:"bytes,6,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	Transactions      []*Transaction `protobuf:"bytes,7,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	ForcedBatchNumber uint64         `protobuf:"varint,8,opt,name=forced_batch_number,json=forcedBatchNumber,proto3" json:"forced_batch_number,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *Batch) Reset()         { *x = Batch{} }
+func (x *Batch) String() string { return formatMessage(x) }
+func (*Batch) ProtoMessage()    {}
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: batchSpec, ptr: x}
+}
+func (x *Batch) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *Batch) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *Batch) GetBatchNumber() uint64 {
+	if x != nil {
+		return x.BatchNumber
+	}
+	return 0
+}
+
+func (x *Batch) GetGlobalExitRoot() string {
+	if x != nil {
+		return x.GlobalExitRoot
+	}
+	return ""
+}
+
+func (x *Batch) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Batch) GetSequencer() string {
+	if x != nil {
+		return x.Sequencer
+	}
+	return ""
+}
+
+func (x *Batch) GetLocalExitRoot() string {
+	if x != nil {
+		return x.LocalExitRoot
+	}
+	return ""
+}
+
+func (x *Batch) GetStateRoot() string {
+	if x != nil {
+		return x.StateRoot
+	}
+	return ""
+}
+
+func (x *Batch) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Batch) GetForcedBatchNumber() uint64 {
+	if x != nil {
+		return x.ForcedBatchNumber
+	}
+	return 0
+}
+
+// EntryType identifies what a StreamEntry's payload represents.
+type EntryType int32
+
+const (
+	EntryType_ENTRY_TYPE_UNSPECIFIED  EntryType = 0
+	EntryType_ENTRY_TYPE_BATCH_START  EntryType = 1
+	EntryType_ENTRY_TYPE_L2_BLOCK     EntryType = 2
+	EntryType_ENTRY_TYPE_TRANSACTION  EntryType = 3
+	EntryType_ENTRY_TYPE_BATCH_END    EntryType = 4
+	EntryType_ENTRY_TYPE_FORCED_BATCH EntryType = 5
+)
+
+var EntryType_name = map[int32]string{
+	0: "ENTRY_TYPE_UNSPECIFIED",
+	1: "ENTRY_TYPE_BATCH_START",
+	2: "ENTRY_TYPE_L2_BLOCK",
+	3: "ENTRY_TYPE_TRANSACTION",
+	4: "ENTRY_TYPE_BATCH_END",
+	5: "ENTRY_TYPE_FORCED_BATCH",
+}
+
+func (x EntryType) String() string {
+	if name, ok := EntryType_name[int32(x)]; ok {
+		return name
+	}
+	return EntryType_name[0]
+}
+
+type StreamFromRequest struct {
+	EntryNumber uint64 `protobuf:"varint,1,opt,name=entry_number,json=entryNumber,proto3" json:"entry_number,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *StreamFromRequest) Reset()         { *x = StreamFromRequest{} }
+func (x *StreamFromRequest) String() string { return formatMessage(x) }
+func (*StreamFromRequest) ProtoMessage()    {}
+func (x *StreamFromRequest) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: streamFromRequestSpec, ptr: x}
+}
+func (x *StreamFromRequest) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *StreamFromRequest) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *StreamFromRequest) GetEntryNumber() uint64 {
+	if x != nil {
+		return x.EntryNumber
+	}
+	return 0
+}
+
+type GetLastEntryNumberResponse struct {
+	EntryNumber uint64 `protobuf:"varint,1,opt,name=entry_number,json=entryNumber,proto3" json:"entry_number,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *GetLastEntryNumberResponse) Reset()         { *x = GetLastEntryNumberResponse{} }
+func (x *GetLastEntryNumberResponse) String() string { return formatMessage(x) }
+func (*GetLastEntryNumberResponse) ProtoMessage()    {}
+func (x *GetLastEntryNumberResponse) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: getLastEntryNumberResponseSpec, ptr: x}
+}
+func (x *GetLastEntryNumberResponse) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *GetLastEntryNumberResponse) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *GetLastEntryNumberResponse) GetEntryNumber() uint64 {
+	if x != nil {
+		return x.EntryNumber
+	}
+	return 0
+}
+
+// StreamEntry is one entry of the datastream. Entry numbers are monotonic,
+// gapless and stable across restarts.
+type StreamEntry struct {
+	EntryNumber uint64    `protobuf:"varint,1,opt,name=entry_number,json=entryNumber,proto3" json:"entry_number,omitempty"`
+	EntryType   EntryType `protobuf:"varint,2,opt,name=entry_type,json=entryType,proto3,enum=broadcast.v1.EntryType" json:"entry_type,omitempty"`
+	Payload     []byte    `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *StreamEntry) Reset()         { *x = StreamEntry{} }
+func (x *StreamEntry) String() string { return formatMessage(x) }
+func (*StreamEntry) ProtoMessage()    {}
+func (x *StreamEntry) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: streamEntrySpec, ptr: x}
+}
+func (x *StreamEntry) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *StreamEntry) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *StreamEntry) GetEntryNumber() uint64 {
+	if x != nil {
+		return x.EntryNumber
+	}
+	return 0
+}
+
+func (x *StreamEntry) GetEntryType() EntryType {
+	if x != nil {
+		return x.EntryType
+	}
+	return EntryType_ENTRY_TYPE_UNSPECIFIED
+}
+
+func (x *StreamEntry) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type Transaction struct {
+	Encoded string `protobuf:"bytes,1,opt,name=encoded,proto3" json:"encoded,omitempty"`
+	Hash    string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+
+	unknownFields protoreflect.RawFields
+}
+
+func (x *Transaction) Reset()         { *x = Transaction{} }
+func (x *Transaction) String() string { return formatMessage(x) }
+func (*Transaction) ProtoMessage()    {}
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	return &reflectMessage{spec: transactionSpec, ptr: x}
+}
+func (x *Transaction) getUnknown() protoreflect.RawFields  { return x.unknownFields }
+func (x *Transaction) setUnknown(b protoreflect.RawFields) { x.unknownFields = b }
+
+func (x *Transaction) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+func (x *Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// --- protoreflect support -----------------------------------------------
+//
+// There is no protoc/protoc-gen-go available to regenerate this package, so
+// the file descriptor below is built by hand from the same shape as
+// broadcast.proto via descriptorpb+protodesc (both genuine, public parts of
+// google.golang.org/protobuf), and each message type is wired up to its real
+// protoreflect.MessageDescriptor through reflectMessage, a small generic
+// protoreflect.Message implementation that reads/writes the struct fields
+// above directly. This is what makes these types satisfy the v2
+// google.golang.org/protobuf/proto.Message interface gRPC's codec requires,
+// instead of only the legacy github.com/golang/protobuf/proto one.
+
+var fileDescriptor protoreflect.FileDescriptor
+
+var (
+	getBatchRequestSpec           *msgSpec
+	subscribeBatchesRequestSpec   *msgSpec
+	batchSpec                     *msgSpec
+	transactionSpec                *msgSpec
+	streamFromRequestSpec         *msgSpec
+	getLastEntryNumberResponseSpec *msgSpec
+	streamEntrySpec                *msgSpec
+)
+
+func init() {
+	fd, err := protodesc.NewFile(buildFileDescriptorProto(), protoregistry.GlobalFiles)
+	if err != nil {
+		panic(fmt.Sprintf("broadcast/pb: failed to build broadcast.proto descriptor: %v", err))
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(fmt.Sprintf("broadcast/pb: failed to register broadcast.proto descriptor: %v", err))
+	}
+	fileDescriptor = fd
+
+	messageDesc := func(name string) protoreflect.MessageDescriptor {
+		md := fd.Messages().ByName(protoreflect.Name(name))
+		if md == nil {
+			panic(fmt.Sprintf("broadcast/pb: message %q missing from descriptor", name))
+		}
+		return md
+	}
+
+	getBatchRequestSpec = &msgSpec{
+		desc:   messageDesc("GetBatchRequest"),
+		newPtr: func() interface{} { return &GetBatchRequest{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*GetBatchRequest).BatchNumber },
+				func(p interface{}, v uint64) { p.(*GetBatchRequest).BatchNumber = v },
+			),
+		},
+	}
+
+	subscribeBatchesRequestSpec = &msgSpec{
+		desc:   messageDesc("SubscribeBatchesRequest"),
+		newPtr: func() interface{} { return &SubscribeBatchesRequest{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*SubscribeBatchesRequest).FromBatchNumber },
+				func(p interface{}, v uint64) { p.(*SubscribeBatchesRequest).FromBatchNumber = v },
+			),
+		},
+	}
+
+	transactionSpec = &msgSpec{
+		desc:   messageDesc("Transaction"),
+		newPtr: func() interface{} { return &Transaction{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: stringField(
+				func(p interface{}) string { return p.(*Transaction).Encoded },
+				func(p interface{}, v string) { p.(*Transaction).Encoded = v },
+			),
+			2: stringField(
+				func(p interface{}) string { return p.(*Transaction).Hash },
+				func(p interface{}, v string) { p.(*Transaction).Hash = v },
+			),
+		},
+	}
+
+	batchSpec = &msgSpec{
+		desc:   messageDesc("Batch"),
+		newPtr: func() interface{} { return &Batch{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*Batch).BatchNumber },
+				func(p interface{}, v uint64) { p.(*Batch).BatchNumber = v },
+			),
+			2: stringField(
+				func(p interface{}) string { return p.(*Batch).GlobalExitRoot },
+				func(p interface{}, v string) { p.(*Batch).GlobalExitRoot = v },
+			),
+			3: int64Field(
+				func(p interface{}) int64 { return p.(*Batch).Timestamp },
+				func(p interface{}, v int64) { p.(*Batch).Timestamp = v },
+			),
+			4: stringField(
+				func(p interface{}) string { return p.(*Batch).Sequencer },
+				func(p interface{}, v string) { p.(*Batch).Sequencer = v },
+			),
+			5: stringField(
+				func(p interface{}) string { return p.(*Batch).LocalExitRoot },
+				func(p interface{}, v string) { p.(*Batch).LocalExitRoot = v },
+			),
+			6: stringField(
+				func(p interface{}) string { return p.(*Batch).StateRoot },
+				func(p interface{}, v string) { p.(*Batch).StateRoot = v },
+			),
+			7: transactionListField(),
+			8: uint64Field(
+				func(p interface{}) uint64 { return p.(*Batch).ForcedBatchNumber },
+				func(p interface{}, v uint64) { p.(*Batch).ForcedBatchNumber = v },
+			),
+		},
+	}
+
+	streamFromRequestSpec = &msgSpec{
+		desc:   messageDesc("StreamFromRequest"),
+		newPtr: func() interface{} { return &StreamFromRequest{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*StreamFromRequest).EntryNumber },
+				func(p interface{}, v uint64) { p.(*StreamFromRequest).EntryNumber = v },
+			),
+		},
+	}
+
+	getLastEntryNumberResponseSpec = &msgSpec{
+		desc:   messageDesc("GetLastEntryNumberResponse"),
+		newPtr: func() interface{} { return &GetLastEntryNumberResponse{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*GetLastEntryNumberResponse).EntryNumber },
+				func(p interface{}, v uint64) { p.(*GetLastEntryNumberResponse).EntryNumber = v },
+			),
+		},
+	}
+
+	streamEntrySpec = &msgSpec{
+		desc:   messageDesc("StreamEntry"),
+		newPtr: func() interface{} { return &StreamEntry{} },
+		fields: map[protoreflect.FieldNumber]*fieldSpec{
+			1: uint64Field(
+				func(p interface{}) uint64 { return p.(*StreamEntry).EntryNumber },
+				func(p interface{}, v uint64) { p.(*StreamEntry).EntryNumber = v },
+			),
+			2: enumField(
+				func(p interface{}) int32 { return int32(p.(*StreamEntry).EntryType) },
+				func(p interface{}, v int32) { p.(*StreamEntry).EntryType = EntryType(v) },
+			),
+			3: bytesField(
+				func(p interface{}) []byte { return p.(*StreamEntry).Payload },
+				func(p interface{}, v []byte) { p.(*StreamEntry).Payload = v },
+			),
+		},
+	}
+}
+
+// buildFileDescriptorProto describes broadcast.proto as a
+// descriptorpb.FileDescriptorProto. It is built directly as Go struct
+// literals rather than parsed from compiled protoc output, since protoc is
+// not available in every environment this package is built in; protodesc
+// validates and links it exactly as it would real protoc output.
+func buildFileDescriptorProto() *descriptorpb.FileDescriptorProto {
+	opt := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	rep := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, jsonName, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+			JsonName: proto.String(jsonName),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+
+	const (
+		tUint64  = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+		tInt64   = descriptorpb.FieldDescriptorProto_TYPE_INT64
+		tString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		tBytes   = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+		tMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		tEnum    = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	)
+
+	entryType := &descriptorpb.EnumDescriptorProto{
+		Name: proto.String("EntryType"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("ENTRY_TYPE_UNSPECIFIED"), Number: proto.Int32(0)},
+			{Name: proto.String("ENTRY_TYPE_BATCH_START"), Number: proto.Int32(1)},
+			{Name: proto.String("ENTRY_TYPE_L2_BLOCK"), Number: proto.Int32(2)},
+			{Name: proto.String("ENTRY_TYPE_TRANSACTION"), Number: proto.Int32(3)},
+			{Name: proto.String("ENTRY_TYPE_BATCH_END"), Number: proto.Int32(4)},
+			{Name: proto.String("ENTRY_TYPE_FORCED_BATCH"), Number: proto.Int32(5)},
+		},
+	}
+
+	method := func(name, inType, outType string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(name),
+			InputType:       proto.String(inType),
+			OutputType:      proto.String(outType),
+			ServerStreaming: proto.Bool(serverStreaming),
+		}
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("broadcast.proto"),
+		Package:    proto.String("broadcast.v1"),
+		Dependency: []string{"google/protobuf/empty.proto"},
+		Syntax:     proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/hermeznetwork/hermez-core/sequencerv2/broadcast/pb"),
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{entryType},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("GetBatchRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("batch_number", 1, tUint64, opt, "batchNumber", ""),
+				},
+			},
+			{
+				Name: proto.String("SubscribeBatchesRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("from_batch_number", 1, tUint64, opt, "fromBatchNumber", ""),
+				},
+			},
+			{
+				Name: proto.String("Batch"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("batch_number", 1, tUint64, opt, "batchNumber", ""),
+					field("global_exit_root", 2, tString, opt, "globalExitRoot", ""),
+					field("timestamp", 3, tInt64, opt, "timestamp", ""),
+					field("sequencer", 4, tString, opt, "sequencer", ""),
+					field("local_exit_root", 5, tString, opt, "localExitRoot", ""),
+					field("state_root", 6, tString, opt, "stateRoot", ""),
+					field("transactions", 7, tMessage, rep, "transactions", ".broadcast.v1.Transaction"),
+					field("forced_batch_number", 8, tUint64, opt, "forcedBatchNumber", ""),
+				},
+			},
+			{
+				Name: proto.String("Transaction"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("encoded", 1, tString, opt, "encoded", ""),
+					field("hash", 2, tString, opt, "hash", ""),
+				},
+			},
+			{
+				Name: proto.String("StreamFromRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("entry_number", 1, tUint64, opt, "entryNumber", ""),
+				},
+			},
+			{
+				Name: proto.String("GetLastEntryNumberResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("entry_number", 1, tUint64, opt, "entryNumber", ""),
+				},
+			},
+			{
+				Name: proto.String("StreamEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("entry_number", 1, tUint64, opt, "entryNumber", ""),
+					field("entry_type", 2, tEnum, opt, "entryType", ".broadcast.v1.EntryType"),
+					field("payload", 3, tBytes, opt, "payload", ""),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("BroadcastService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					method("GetLastBatch", ".google.protobuf.Empty", ".broadcast.v1.Batch", false),
+					method("GetBatch", ".broadcast.v1.GetBatchRequest", ".broadcast.v1.Batch", false),
+					method("SubscribeBatches", ".broadcast.v1.SubscribeBatchesRequest", ".broadcast.v1.Batch", true),
+					method("StreamFrom", ".broadcast.v1.StreamFromRequest", ".broadcast.v1.StreamEntry", true),
+					method("GetLastEntryNumber", ".google.protobuf.Empty", ".broadcast.v1.GetLastEntryNumberResponse", false),
+				},
+			},
+		},
+	}
+}
+
+// formatMessage renders m via prototext rather than the Stringer itself, so
+// the per-type String() methods above don't recurse into their own %v
+// formatting.
+func formatMessage(m protoreflect.ProtoMessage) string {
+	return prototext.Format(m)
+}
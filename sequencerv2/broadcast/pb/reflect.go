@@ -0,0 +1,237 @@
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// msgSpec binds one of this package's hand-written structs to its real
+// protoreflect.MessageDescriptor and to accessors for each of its fields, by
+// field number. reflectMessage uses it to implement protoreflect.Message
+// generically, without per-type boilerplate.
+type msgSpec struct {
+	desc   protoreflect.MessageDescriptor
+	newPtr func() interface{}
+	fields map[protoreflect.FieldNumber]*fieldSpec
+}
+
+type fieldSpec struct {
+	get     func(ptr interface{}) protoreflect.Value
+	set     func(ptr interface{}, v protoreflect.Value)
+	has     func(ptr interface{}) bool
+	clear   func(ptr interface{})
+	mutable func(ptr interface{}) protoreflect.Value // non-nil only for list/message fields
+}
+
+func uint64Field(get func(interface{}) uint64, set func(interface{}, uint64)) *fieldSpec {
+	return &fieldSpec{
+		get:   func(p interface{}) protoreflect.Value { return protoreflect.ValueOfUint64(get(p)) },
+		set:   func(p interface{}, v protoreflect.Value) { set(p, v.Uint()) },
+		has:   func(p interface{}) bool { return get(p) != 0 },
+		clear: func(p interface{}) { set(p, 0) },
+	}
+}
+
+func int64Field(get func(interface{}) int64, set func(interface{}, int64)) *fieldSpec {
+	return &fieldSpec{
+		get:   func(p interface{}) protoreflect.Value { return protoreflect.ValueOfInt64(get(p)) },
+		set:   func(p interface{}, v protoreflect.Value) { set(p, v.Int()) },
+		has:   func(p interface{}) bool { return get(p) != 0 },
+		clear: func(p interface{}) { set(p, 0) },
+	}
+}
+
+func stringField(get func(interface{}) string, set func(interface{}, string)) *fieldSpec {
+	return &fieldSpec{
+		get:   func(p interface{}) protoreflect.Value { return protoreflect.ValueOfString(get(p)) },
+		set:   func(p interface{}, v protoreflect.Value) { set(p, v.String()) },
+		has:   func(p interface{}) bool { return get(p) != "" },
+		clear: func(p interface{}) { set(p, "") },
+	}
+}
+
+func bytesField(get func(interface{}) []byte, set func(interface{}, []byte)) *fieldSpec {
+	return &fieldSpec{
+		get:   func(p interface{}) protoreflect.Value { return protoreflect.ValueOfBytes(get(p)) },
+		set:   func(p interface{}, v protoreflect.Value) { set(p, v.Bytes()) },
+		has:   func(p interface{}) bool { return len(get(p)) > 0 },
+		clear: func(p interface{}) { set(p, nil) },
+	}
+}
+
+func enumField(get func(interface{}) int32, set func(interface{}, int32)) *fieldSpec {
+	return &fieldSpec{
+		get: func(p interface{}) protoreflect.Value {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(get(p)))
+		},
+		set:   func(p interface{}, v protoreflect.Value) { set(p, int32(v.Enum())) },
+		has:   func(p interface{}) bool { return get(p) != 0 },
+		clear: func(p interface{}) { set(p, 0) },
+	}
+}
+
+// transactionListField binds Batch.Transactions, the package's one repeated
+// message field.
+func transactionListField() *fieldSpec {
+	list := func(p interface{}) protoreflect.Value {
+		return protoreflect.ValueOfList(&transactionList{txs: &p.(*Batch).Transactions})
+	}
+	return &fieldSpec{
+		get: list,
+		set: func(p interface{}, v protoreflect.Value) {
+			src := v.List()
+			txs := make([]*Transaction, src.Len())
+			for i := 0; i < src.Len(); i++ {
+				txs[i] = src.Get(i).Message().Interface().(*Transaction)
+			}
+			p.(*Batch).Transactions = txs
+		},
+		has:     func(p interface{}) bool { return len(p.(*Batch).Transactions) > 0 },
+		clear:   func(p interface{}) { p.(*Batch).Transactions = nil },
+		mutable: list,
+	}
+}
+
+// transactionList implements protoreflect.List over a live *[]*Transaction,
+// so Mutable/AppendMutable decode directly into the real Batch struct instead
+// of a disconnected copy.
+type transactionList struct {
+	txs *[]*Transaction
+}
+
+func (l *transactionList) Len() int { return len(*l.txs) }
+
+func (l *transactionList) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfMessage((*l.txs)[i].ProtoReflect())
+}
+
+func (l *transactionList) Set(i int, v protoreflect.Value) {
+	(*l.txs)[i] = v.Message().Interface().(*Transaction)
+}
+
+func (l *transactionList) Append(v protoreflect.Value) {
+	*l.txs = append(*l.txs, v.Message().Interface().(*Transaction))
+}
+
+func (l *transactionList) AppendMutable() protoreflect.Value {
+	tx := &Transaction{}
+	*l.txs = append(*l.txs, tx)
+	return protoreflect.ValueOfMessage(tx.ProtoReflect())
+}
+
+func (l *transactionList) Truncate(n int) { *l.txs = (*l.txs)[:n] }
+
+func (l *transactionList) NewElement() protoreflect.Value {
+	return protoreflect.ValueOfMessage((&Transaction{}).ProtoReflect())
+}
+
+func (l *transactionList) IsValid() bool { return l.txs != nil }
+
+// unknownFieldsHolder is implemented by every message struct in this package
+// so reflectMessage can preserve unrecognized wire fields across
+// decode/re-encode, same as real generated code does.
+type unknownFieldsHolder interface {
+	getUnknown() protoreflect.RawFields
+	setUnknown(protoreflect.RawFields)
+}
+
+// reflectMessage is a generic protoreflect.Message backed by a msgSpec and a
+// concrete struct pointer. It reads and writes the struct's own fields
+// directly (via the closures in msgSpec.fields), so it stays a thin,
+// stateless view rather than a separate copy of the data.
+type reflectMessage struct {
+	spec *msgSpec
+	ptr  interface{}
+}
+
+func (m *reflectMessage) Descriptor() protoreflect.MessageDescriptor { return m.spec.desc }
+
+func (m *reflectMessage) Type() protoreflect.MessageType { return msgType{m.spec} }
+
+func (m *reflectMessage) New() protoreflect.Message {
+	return &reflectMessage{spec: m.spec, ptr: m.spec.newPtr()}
+}
+
+func (m *reflectMessage) Interface() protoreflect.ProtoMessage {
+	return m.ptr.(protoreflect.ProtoMessage)
+}
+
+func (m *reflectMessage) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	fields := m.spec.desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		spec := m.spec.fields[fd.Number()]
+		if spec == nil || !spec.has(m.ptr) {
+			continue
+		}
+		if !f(fd, spec.get(m.ptr)) {
+			return
+		}
+	}
+}
+
+func (m *reflectMessage) Has(fd protoreflect.FieldDescriptor) bool {
+	spec := m.spec.fields[fd.Number()]
+	return spec != nil && spec.has(m.ptr)
+}
+
+func (m *reflectMessage) Clear(fd protoreflect.FieldDescriptor) {
+	if spec := m.spec.fields[fd.Number()]; spec != nil {
+		spec.clear(m.ptr)
+	}
+}
+
+func (m *reflectMessage) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if spec := m.spec.fields[fd.Number()]; spec != nil {
+		return spec.get(m.ptr)
+	}
+	return fd.Default()
+}
+
+func (m *reflectMessage) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	if spec := m.spec.fields[fd.Number()]; spec != nil {
+		spec.set(m.ptr, v)
+	}
+}
+
+func (m *reflectMessage) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	spec := m.spec.fields[fd.Number()]
+	if spec == nil || spec.mutable == nil {
+		panic(fmt.Sprintf("broadcast/pb: field %s has no mutable accessor", fd.FullName()))
+	}
+	return spec.mutable(m.ptr)
+}
+
+func (m *reflectMessage) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if spec := m.spec.fields[fd.Number()]; spec != nil && spec.mutable != nil {
+		return spec.mutable(m.ptr)
+	}
+	return fd.Default()
+}
+
+func (m *reflectMessage) WhichOneof(protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	return nil // none of these messages declare a oneof
+}
+
+func (m *reflectMessage) GetUnknown() protoreflect.RawFields {
+	if h, ok := m.ptr.(unknownFieldsHolder); ok {
+		return h.getUnknown()
+	}
+	return nil
+}
+
+func (m *reflectMessage) SetUnknown(b protoreflect.RawFields) {
+	if h, ok := m.ptr.(unknownFieldsHolder); ok {
+		h.setUnknown(b)
+	}
+}
+
+func (m *reflectMessage) IsValid() bool { return m.ptr != nil }
+
+// msgType is the protoreflect.MessageType counterpart of reflectMessage.
+type msgType struct{ spec *msgSpec }
+
+func (t msgType) New() protoreflect.Message { return &reflectMessage{spec: t.spec, ptr: t.spec.newPtr()} }
+func (t msgType) Zero() protoreflect.Message { return t.New() }
+func (t msgType) Descriptor() protoreflect.MessageDescriptor { return t.spec.desc }
@@ -0,0 +1,304 @@
+package broadcast
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hermeznetwork/hermez-core/log"
+	"github.com/hermeznetwork/hermez-core/sequencerv2/broadcast/pb"
+	"github.com/hermeznetwork/hermez-core/statev2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const defaultPollingInterval = 100 * time.Millisecond
+
+const (
+	getLastBatchNumberSQL = "SELECT COALESCE(MAX(batch_num), 0) FROM statev2.batch"
+
+	getBatchSQL = `
+		SELECT b.batch_num, b.global_exit_root, b.timestamp, b.sequencer, b.local_exit_root, b.state_root,
+		       COALESCE(fb.forced_batch_num, 0)
+		FROM statev2.batch b
+		LEFT JOIN statev2.forced_batch fb ON fb.batch_num = b.batch_num
+		WHERE b.batch_num = $1`
+
+	getBatchTransactionsSQL = `
+		SELECT encoded, hash
+		FROM statev2.transaction
+		WHERE batch_num = $1
+		ORDER BY l2_block_num ASC`
+)
+
+// subscription is a single SubscribeBatches caller waiting for newly sealed
+// batches.
+type subscription struct {
+	batches chan *pb.Batch
+}
+
+// Server implements the BroadcastService gRPC service. It serves sealed
+// batches out of the state DB and fans out newly sealed batches to every
+// open SubscribeBatches stream, so followers no longer have to poll.
+type Server struct {
+	pb.UnimplementedBroadcastServiceServer
+
+	cfg   Config
+	state *statev2.State
+	srv   *grpc.Server
+
+	mu            sync.Mutex
+	subscriptions map[string]*subscription
+	lastBatchSeen uint64
+
+	stopPolling chan struct{}
+}
+
+// NewServer creates a broadcast Server backed by the given state DB.
+func NewServer(cfg Config, state *statev2.State) *Server {
+	if cfg.PollingInterval == 0 {
+		cfg.PollingInterval = defaultPollingInterval
+	}
+	return &Server{
+		cfg:           cfg,
+		state:         state,
+		subscriptions: make(map[string]*subscription),
+		stopPolling:   make(chan struct{}),
+	}
+}
+
+// Start starts serving the BroadcastService on cfg.Host:cfg.Port and starts
+// the background poller that detects newly sealed batches.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensureStreamEntryView(context.Background()); err != nil {
+		return err
+	}
+
+	opts, err := s.serverOptions()
+	if err != nil {
+		return err
+	}
+	s.srv = grpc.NewServer(opts...)
+	pb.RegisterBroadcastServiceServer(s.srv, s)
+
+	s.serveMetrics()
+	go s.pollNewBatches()
+
+	log.Infof("broadcast server listening on %s", lis.Addr())
+	return s.srv.Serve(lis)
+}
+
+// Stop gracefully stops the server and the background poller.
+func (s *Server) Stop() {
+	close(s.stopPolling)
+	if s.srv != nil {
+		s.srv.GracefulStop()
+	}
+}
+
+// GetLastBatch returns the most recently sealed batch.
+func (s *Server) GetLastBatch(ctx context.Context, _ *emptypb.Empty) (*pb.Batch, error) {
+	start := time.Now()
+	defer func() { getLastBatchDuration.Observe(time.Since(start).Seconds()) }()
+
+	dbStart := time.Now()
+	lastBatchNumber, err := s.getLastBatchNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := s.getBatch(ctx, lastBatchNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.logRPCTimers("GetLastBatch", lastBatchNumber, start, dbStart, batch)
+	return batch, nil
+}
+
+// GetBatch returns the batch with the given batch number.
+func (s *Server) GetBatch(ctx context.Context, req *pb.GetBatchRequest) (*pb.Batch, error) {
+	start := time.Now()
+	defer func() { getBatchDuration.Observe(time.Since(start).Seconds()) }()
+
+	dbStart := time.Now()
+	batch, err := s.getBatch(ctx, req.BatchNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.logRPCTimers("GetBatch", req.BatchNumber, start, dbStart, batch)
+	return batch, nil
+}
+
+// SubscribeBatches back-fills every sealed batch starting at
+// req.FromBatchNumber and then blocks, pushing newly sealed batches to the
+// caller as they land.
+func (s *Server) SubscribeBatches(req *pb.SubscribeBatchesRequest, stream pb.BroadcastService_SubscribeBatchesServer) error {
+	ctx := stream.Context()
+
+	// Register the subscription before reading lastBatchNumber: any batch
+	// sealed after that point is offered to sub.batches by the poller, so the
+	// drain loop below can simply skip numbers the back-fill already sent
+	// instead of risking a batch falling in the gap between the two. A batch
+	// that arrives while sub.batches is full is dropped by broadcast.
+	sub := &subscription{batches: make(chan *pb.Batch, 16)}
+	id := uuid.NewString()
+	s.addSubscription(id, sub)
+	defer s.removeSubscription(id)
+
+	lastBatchNumber, err := s.getLastBatchNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	for batchNumber := req.FromBatchNumber; batchNumber <= lastBatchNumber; batchNumber++ {
+		batch, err := s.getBatch(ctx, batchNumber)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(batch); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case batch := <-sub.batches:
+			if batch.BatchNumber <= lastBatchNumber {
+				// already sent during the back-fill above
+				continue
+			}
+			if err := stream.Send(batch); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Server) addSubscription(id string, sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions[id] = sub
+}
+
+func (s *Server) removeSubscription(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscriptions, id)
+}
+
+// pollNewBatches periodically checks the state DB for newly sealed batches
+// and fans them out to every open subscription. This stands in for the
+// Postgres LISTEN/NOTIFY channel: a follower never needs to reconnect to
+// notice a new batch, it only needs to keep its stream open.
+func (s *Server) pollNewBatches() {
+	ticker := time.NewTicker(s.cfg.PollingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.checkForNewBatches()
+		case <-s.stopPolling:
+			return
+		}
+	}
+}
+
+func (s *Server) checkForNewBatches() {
+	ctx := context.Background()
+	lastBatchNumber, err := s.getLastBatchNumber(ctx)
+	if err != nil {
+		log.Errorf("failed to poll last batch number: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	lastSeen := s.lastBatchSeen
+	s.mu.Unlock()
+
+	for batchNumber := lastSeen + 1; batchNumber <= lastBatchNumber; batchNumber++ {
+		batch, err := s.getBatch(ctx, batchNumber)
+		if err != nil {
+			log.Errorf("failed to load batch %d to broadcast: %v", batchNumber, err)
+			return
+		}
+		s.broadcast(batch)
+	}
+
+	s.mu.Lock()
+	if lastBatchNumber > s.lastBatchSeen {
+		s.lastBatchSeen = lastBatchNumber
+	}
+	s.mu.Unlock()
+}
+
+func (s *Server) broadcast(batch *pb.Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscriptions {
+		select {
+		case sub.batches <- batch:
+		default:
+			log.Warnf("subscriber falling behind, dropping batch %d", batch.BatchNumber)
+		}
+	}
+}
+
+func (s *Server) getLastBatchNumber(ctx context.Context) (uint64, error) {
+	var lastBatchNumber uint64
+	_, err := s.timedDBQuery("get_last_batch_number", func() error {
+		return s.state.PostgresStorage.QueryRow(ctx, getLastBatchNumberSQL).Scan(&lastBatchNumber)
+	})
+	return lastBatchNumber, err
+}
+
+func (s *Server) getBatch(ctx context.Context, batchNumber uint64) (*pb.Batch, error) {
+	batch := &pb.Batch{}
+	var timestamp time.Time
+	_, err := s.timedDBQuery("get_batch", func() error {
+		return s.state.PostgresStorage.QueryRow(ctx, getBatchSQL, batchNumber).Scan(
+			&batch.BatchNumber,
+			&batch.GlobalExitRoot,
+			&timestamp,
+			&batch.Sequencer,
+			&batch.LocalExitRoot,
+			&batch.StateRoot,
+			&batch.ForcedBatchNumber,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	batch.Timestamp = timestamp.Unix()
+
+	_, err = s.timedDBQuery("get_batch_transactions", func() error {
+		rows, err := s.state.PostgresStorage.Query(ctx, getBatchTransactionsSQL, batchNumber)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			tx := &pb.Transaction{}
+			if err := rows.Scan(&tx.Encoded, &tx.Hash); err != nil {
+				return err
+			}
+			batch.Transactions = append(batch.Transactions, tx)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
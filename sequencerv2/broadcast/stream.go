@@ -0,0 +1,236 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/hermeznetwork/hermez-core/sequencerv2/broadcast/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ensureStreamEntryViewSQL derives a gapless, restart-stable entry_number for
+// every batch-start, l2 block, transaction, batch-end and forced batch
+// already persisted in statev2, so the datastream never needs a counter of
+// its own: the ordering is recomputed from the same rows GetBatch reads.
+const ensureStreamEntryViewSQL = `
+	CREATE OR REPLACE VIEW statev2.stream_entry AS
+	SELECT
+		ROW_NUMBER() OVER (ORDER BY sort_batch, sort_phase, sort_block, sort_tx) AS entry_number,
+		entry_type,
+		ref_id
+	FROM (
+		SELECT batch_num AS sort_batch, 0 AS sort_phase, 0 AS sort_block, 0 AS sort_tx,
+		       'ENTRY_TYPE_BATCH_START' AS entry_type, batch_num::text AS ref_id
+		FROM statev2.batch
+
+		UNION ALL
+
+		SELECT batch_num, 1, block_num, 0,
+		       'ENTRY_TYPE_L2_BLOCK', block_num::text
+		FROM statev2.l2_block
+
+		UNION ALL
+
+		SELECT batch_num, 1, l2_block_num,
+		       ROW_NUMBER() OVER (PARTITION BY batch_num, l2_block_num ORDER BY received_at),
+		       'ENTRY_TYPE_TRANSACTION', hash
+		FROM statev2.transaction
+
+		UNION ALL
+
+		SELECT batch_num, 2, 0, 0,
+		       'ENTRY_TYPE_BATCH_END', batch_num::text
+		FROM statev2.batch
+
+		UNION ALL
+
+		SELECT batch_num, 3, 0, forced_batch_num,
+		       'ENTRY_TYPE_FORCED_BATCH', forced_batch_num::text
+		FROM statev2.forced_batch
+	) raw`
+
+const getLastEntryNumberSQL = "SELECT COALESCE(MAX(entry_number), 0) FROM statev2.stream_entry"
+
+const getStreamEntriesFromSQL = `
+	SELECT entry_number, entry_type, ref_id
+	FROM statev2.stream_entry
+	WHERE entry_number >= $1
+	ORDER BY entry_number ASC`
+
+const getTransactionByHashSQL = `
+	SELECT encoded, hash, l2_block_num
+	FROM statev2.transaction
+	WHERE hash = $1`
+
+const getForcedBatchByNumberSQL = `
+	SELECT forced_batch_num, global_exit_root, sequencer, timestamp, batch_num
+	FROM statev2.forced_batch
+	WHERE forced_batch_num = $1`
+
+// ensureStreamEntryView (re)creates the stream_entry view. It is safe to run
+// on every Start: CREATE OR REPLACE VIEW is idempotent.
+func (s *Server) ensureStreamEntryView(ctx context.Context) error {
+	_, err := s.state.PostgresStorage.Exec(ctx, ensureStreamEntryViewSQL)
+	return err
+}
+
+// GetLastEntryNumber returns the entry_number of the most recent StreamEntry.
+func (s *Server) GetLastEntryNumber(ctx context.Context, _ *emptypb.Empty) (*pb.GetLastEntryNumberResponse, error) {
+	var entryNumber uint64
+	err := s.state.PostgresStorage.QueryRow(ctx, getLastEntryNumberSQL).Scan(&entryNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetLastEntryNumberResponse{EntryNumber: entryNumber}, nil
+}
+
+// StreamFrom back-fills every StreamEntry from req.EntryNumber onwards and
+// then blocks, pushing new entries as they are produced, the same way
+// SubscribeBatches does for whole batches.
+func (s *Server) StreamFrom(req *pb.StreamFromRequest, stream pb.BroadcastService_StreamFromServer) error {
+	ctx := stream.Context()
+
+	entries, err := s.getStreamEntriesFrom(ctx, req.EntryNumber)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := stream.Send(entry); err != nil {
+			return err
+		}
+		streamEntriesTotal.Inc()
+	}
+
+	nextEntry := req.EntryNumber
+	if len(entries) > 0 {
+		nextEntry = entries[len(entries)-1].EntryNumber + 1
+	}
+
+	ticker := time.NewTicker(s.cfg.PollingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			newEntries, err := s.getStreamEntriesFrom(ctx, nextEntry)
+			if err != nil {
+				return err
+			}
+			for _, entry := range newEntries {
+				if err := stream.Send(entry); err != nil {
+					return err
+				}
+				streamEntriesTotal.Inc()
+				nextEntry = entry.EntryNumber + 1
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Server) getStreamEntriesFrom(ctx context.Context, fromEntryNumber uint64) ([]*pb.StreamEntry, error) {
+	rows, err := s.state.PostgresStorage.Query(ctx, getStreamEntriesFromSQL, fromEntryNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*pb.StreamEntry
+	for rows.Next() {
+		var entryNumber uint64
+		var entryType, refID string
+		if err := rows.Scan(&entryNumber, &entryType, &refID); err != nil {
+			return nil, err
+		}
+
+		payload, err := s.buildPayload(ctx, entryType, refID)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &pb.StreamEntry{
+			EntryNumber: entryNumber,
+			EntryType:   entryTypeFromString(entryType),
+			Payload:     payload,
+		})
+	}
+	return entries, rows.Err()
+}
+
+func entryTypeFromString(entryType string) pb.EntryType {
+	switch entryType {
+	case "ENTRY_TYPE_BATCH_START":
+		return pb.EntryType_ENTRY_TYPE_BATCH_START
+	case "ENTRY_TYPE_L2_BLOCK":
+		return pb.EntryType_ENTRY_TYPE_L2_BLOCK
+	case "ENTRY_TYPE_TRANSACTION":
+		return pb.EntryType_ENTRY_TYPE_TRANSACTION
+	case "ENTRY_TYPE_BATCH_END":
+		return pb.EntryType_ENTRY_TYPE_BATCH_END
+	case "ENTRY_TYPE_FORCED_BATCH":
+		return pb.EntryType_ENTRY_TYPE_FORCED_BATCH
+	default:
+		return pb.EntryType_ENTRY_TYPE_UNSPECIFIED
+	}
+}
+
+// batchEntryPayload is the payload of ENTRY_TYPE_BATCH_START/BATCH_END entries.
+type batchEntryPayload struct {
+	BatchNumber uint64 `json:"batchNumber"`
+}
+
+// l2BlockEntryPayload is the payload of ENTRY_TYPE_L2_BLOCK entries.
+type l2BlockEntryPayload struct {
+	L2BlockNumber uint64 `json:"l2BlockNumber"`
+}
+
+// transactionEntryPayload is the payload of ENTRY_TYPE_TRANSACTION entries.
+type transactionEntryPayload struct {
+	Encoded       string `json:"encoded"`
+	Hash          string `json:"hash"`
+	L2BlockNumber uint64 `json:"l2BlockNumber"`
+}
+
+// forcedBatchEntryPayload is the payload of ENTRY_TYPE_FORCED_BATCH entries.
+type forcedBatchEntryPayload struct {
+	ForcedBatchNumber uint64 `json:"forcedBatchNumber"`
+	GlobalExitRoot    string `json:"globalExitRoot"`
+	Sequencer         string `json:"sequencer"`
+	BatchNumber       uint64 `json:"batchNumber"`
+}
+
+func parseUint64(s string) uint64 {
+	n, _ := strconv.ParseUint(s, 10, 64)
+	return n
+}
+
+func (s *Server) buildPayload(ctx context.Context, entryType, refID string) ([]byte, error) {
+	switch entryType {
+	case "ENTRY_TYPE_BATCH_START", "ENTRY_TYPE_BATCH_END":
+		return json.Marshal(batchEntryPayload{BatchNumber: parseUint64(refID)})
+	case "ENTRY_TYPE_L2_BLOCK":
+		return json.Marshal(l2BlockEntryPayload{L2BlockNumber: parseUint64(refID)})
+	case "ENTRY_TYPE_TRANSACTION":
+		var tx transactionEntryPayload
+		err := s.state.PostgresStorage.QueryRow(ctx, getTransactionByHashSQL, refID).
+			Scan(&tx.Encoded, &tx.Hash, &tx.L2BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(tx)
+	case "ENTRY_TYPE_FORCED_BATCH":
+		var fb forcedBatchEntryPayload
+		var timestamp interface{}
+		err := s.state.PostgresStorage.QueryRow(ctx, getForcedBatchByNumberSQL, parseUint64(refID)).
+			Scan(&fb.ForcedBatchNumber, &fb.GlobalExitRoot, &fb.Sequencer, &timestamp, &fb.BatchNumber)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(fb)
+	default:
+		return nil, nil
+	}
+}